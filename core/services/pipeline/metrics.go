@@ -0,0 +1,21 @@
+package pipeline
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// promTaskDuration and promTaskErrors are exposed on the node's existing
+// /metrics endpoint alongside the rest of the application's Prometheus
+// metrics.
+var (
+	promTaskDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "chainlink_pipeline_task_duration_seconds",
+		Help: "How long a pipeline task took to run",
+	}, []string{"task_type", "dot_id", "job_id"})
+
+	promTaskErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "chainlink_pipeline_task_errors_total",
+		Help: "The number of errors a pipeline task has produced",
+	}, []string{"task_type", "dot_id", "job_id"})
+)
@@ -0,0 +1,70 @@
+package pipeline
+
+import (
+	"database/sql"
+	"time"
+)
+
+// ORM is the persistence layer for pipeline Runs and TaskRuns.
+type ORM interface {
+	RunRecorder
+	// CreateRun inserts the parent pipeline_runs row that every TaskRun's
+	// pipeline_run_id must reference, and returns its ID.
+	CreateRun(jobID int32, createdAt time.Time) (int64, error)
+	FindTaskRunsByPipelineRunID(pipelineRunID int64) ([]TaskRun, error)
+}
+
+type orm struct {
+	db *sql.DB
+}
+
+// NewORM returns an ORM backed by the node's existing database connection.
+func NewORM(db *sql.DB) ORM {
+	return &orm{db: db}
+}
+
+// CreateRun inserts a new pipeline_runs row and returns its ID. Callers
+// must do this before passing the resulting ID to WithObservability, since
+// pipeline_task_runs.pipeline_run_id is a foreign key into this table.
+func (o *orm) CreateRun(jobID int32, createdAt time.Time) (int64, error) {
+	var runID int64
+	err := o.db.QueryRow(`
+        INSERT INTO pipeline_runs (job_id, created_at) VALUES ($1, $2) RETURNING id
+    `, jobID, createdAt).Scan(&runID)
+	return runID, err
+}
+
+// RecordTaskRun persists a single TaskRun, as produced by the Scheduler
+// after every Task.Run invocation.
+func (o *orm) RecordTaskRun(run TaskRun) error {
+	_, err := o.db.Exec(`
+        INSERT INTO pipeline_task_runs (pipeline_run_id, dot_id, input, output, error, created_at, finished_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `, run.PipelineRunID, run.DotID, run.Input, run.Output, run.Error, run.CreatedAt, run.FinishedAt)
+	return err
+}
+
+// FindTaskRunsByPipelineRunID backs the node API's pipeline run debug
+// endpoint, so an operator can inspect a misbehaving bridge or data source
+// without grepping logs.
+func (o *orm) FindTaskRunsByPipelineRunID(pipelineRunID int64) ([]TaskRun, error) {
+	rows, err := o.db.Query(`
+        SELECT id, pipeline_run_id, dot_id, input, output, error, created_at, finished_at
+        FROM pipeline_task_runs
+        WHERE pipeline_run_id = $1
+    `, pipelineRunID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var taskRuns []TaskRun
+	for rows.Next() {
+		var run TaskRun
+		if err := rows.Scan(&run.ID, &run.PipelineRunID, &run.DotID, &run.Input, &run.Output, &run.Error, &run.CreatedAt, &run.FinishedAt); err != nil {
+			return nil, err
+		}
+		taskRuns = append(taskRuns, run)
+	}
+	return taskRuns, rows.Err()
+}
@@ -0,0 +1,92 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"time"
+	"unicode/utf8"
+)
+
+// maxSnapshotLen bounds how much of a TaskRun's input/output we keep, so
+// that a bridge returning a multi-megabyte payload doesn't bloat the
+// pipeline_task_runs table.
+const maxSnapshotLen = 4096
+
+// Run is a single execution of an oracle job's pipeline, made up of one
+// TaskRun per Task.Run invocation, so a task that was retried has one
+// TaskRun per attempt.
+type Run struct {
+	ID         int64      `json:"id"`
+	JobID      int32      `json:"jobId"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	FinishedAt *time.Time `json:"finishedAt"`
+	TaskRuns   []TaskRun  `json:"taskRuns"`
+}
+
+// TaskRun records one Task.Run invocation, so that a misbehaving bridge or
+// data source can be diagnosed from the DB instead of by grepping logs.
+type TaskRun struct {
+	ID            int64      `json:"id"`
+	PipelineRunID int64      `json:"pipelineRunId"`
+	DotID         string     `json:"dotId"`
+	Input         string     `json:"input"`
+	Output        string     `json:"output"`
+	Error         *string    `json:"error"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	FinishedAt    *time.Time `json:"finishedAt"`
+}
+
+// Duration returns zero for a TaskRun that hasn't finished yet.
+func (r TaskRun) Duration() time.Duration {
+	if r.FinishedAt == nil {
+		return 0
+	}
+	return r.FinishedAt.Sub(r.CreatedAt)
+}
+
+// NewTaskRun builds a TaskRun snapshot from a completed (or failed) task
+// invocation, truncating the input/output so that large payloads don't
+// blow out storage.
+func NewTaskRun(pipelineRunID int64, dotID string, inputs []Result, result Result, startedAt, finishedAt time.Time) TaskRun {
+	run := TaskRun{
+		PipelineRunID: pipelineRunID,
+		DotID:         dotID,
+		Input:         truncateSnapshot(snapshotResults(inputs)),
+		Output:        truncateSnapshot(snapshotValue(result.Value)),
+		CreatedAt:     startedAt,
+		FinishedAt:    &finishedAt,
+	}
+	if result.Error != nil {
+		errString := result.Error.Error()
+		run.Error = &errString
+	}
+	return run
+}
+
+func snapshotResults(inputs []Result) string {
+	values := make([]interface{}, len(inputs))
+	for i, input := range inputs {
+		values[i] = input.Value
+	}
+	return snapshotValue(values)
+}
+
+func snapshotValue(v interface{}) string {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(bs)
+}
+
+// truncateSnapshot cuts s to at most maxSnapshotLen bytes, backing off to
+// the nearest rune boundary so it never splits a multi-byte UTF-8 character.
+func truncateSnapshot(s string) string {
+	if len(s) <= maxSnapshotLen {
+		return s
+	}
+	cut := maxSnapshotLen
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
+}
@@ -0,0 +1,92 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRecorder struct {
+	mu       sync.Mutex
+	taskRuns []TaskRun
+	failWith error
+}
+
+func (f *fakeRecorder) RecordTaskRun(run TaskRun) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failWith != nil {
+		return f.failWith
+	}
+	f.taskRuns = append(f.taskRuns, run)
+	return nil
+}
+
+func TestScheduler_RecordsTaskRunsWhenObservabilityIsConfigured(t *testing.T) {
+	g := NewTaskDAG()
+	err := g.UnmarshalText([]byte(dotStr))
+	require.NoError(t, err)
+
+	recorder := &fakeRecorder{}
+	s, err := NewScheduler(g, WithObservability(1, 42, recorder))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = s.Run(ctx)
+	require.NoError(t, err)
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	require.NotEmpty(t, recorder.taskRuns)
+	for _, run := range recorder.taskRuns {
+		require.Equal(t, int64(42), run.PipelineRunID)
+		require.NotEmpty(t, run.DotID)
+		require.NotNil(t, run.FinishedAt)
+	}
+}
+
+func TestScheduler_SurfacesRecorderFailuresWithoutFailingTheTask(t *testing.T) {
+	g := NewTaskDAG()
+	err := g.UnmarshalText([]byte(dotStr))
+	require.NoError(t, err)
+
+	recorder := &fakeRecorder{failWith: errors.New("pipeline_task_runs: foreign key violation")}
+	s, err := NewScheduler(g, WithObservability(1, 42, recorder))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = s.Run(ctx)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, s.PersistErrors())
+}
+
+func TestTaskRun_Duration(t *testing.T) {
+	startedAt := time.Now()
+	finishedAt := startedAt.Add(250 * time.Millisecond)
+	run := TaskRun{CreatedAt: startedAt, FinishedAt: &finishedAt}
+	require.Equal(t, 250*time.Millisecond, run.Duration())
+
+	unfinished := TaskRun{CreatedAt: startedAt}
+	require.Zero(t, unfinished.Duration())
+}
+
+func TestTruncateSnapshot_DoesNotSplitUTF8Runes(t *testing.T) {
+	s := ""
+	for len(s) < maxSnapshotLen+10 {
+		s += "€"
+	}
+
+	truncated := truncateSnapshot(s)
+	require.LessOrEqual(t, len(truncated), maxSnapshotLen)
+	require.True(t, utf8.ValidString(truncated))
+}
@@ -0,0 +1,449 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"gonum.org/v1/gonum/graph/encoding"
+)
+
+// RetryPolicy controls how a Scheduler retries a task that returns a
+// non-nil Result.Error before giving up on it (and, transitively, on
+// every task downstream of it).
+type RetryPolicy struct {
+	MaxRetries     int
+	Backoff        string // "exponential" or "constant"
+	BackoffInitial time.Duration
+	BackoffMax     time.Duration
+	Timeout        time.Duration
+}
+
+var defaultRetryPolicy = RetryPolicy{
+	Backoff:        "constant",
+	BackoffInitial: 500 * time.Millisecond,
+	BackoffMax:     30 * time.Second,
+}
+
+// retryPolicyFromAttrs reads the optional maxRetries/backoff/backoffInitial/
+// backoffMax/timeout DOT attributes off of a task node, falling back to
+// defaultRetryPolicy for anything left unspecified.
+func retryPolicyFromAttrs(attrs []encoding.Attribute) RetryPolicy {
+	policy := defaultRetryPolicy
+	for _, attr := range attrs {
+		switch attr.Key {
+		case "maxRetries":
+			if n, err := strconv.Atoi(attr.Value); err == nil {
+				policy.MaxRetries = n
+			}
+		case "backoff":
+			policy.Backoff = attr.Value
+		case "backoffInitial":
+			if d, err := time.ParseDuration(attr.Value); err == nil {
+				policy.BackoffInitial = d
+			}
+		case "backoffMax":
+			if d, err := time.ParseDuration(attr.Value); err == nil {
+				policy.BackoffMax = d
+			}
+		case "timeout":
+			if d, err := time.ParseDuration(attr.Value); err == nil {
+				policy.Timeout = d
+			}
+		}
+	}
+	return policy
+}
+
+// backoffDuration implements delay = min(backoffMax, initial * 2^attempt) *
+// (1 + jitter), jitter ~ Uniform[-0.5, 0.5], for "exponential" backoff, or a
+// flat `initial` delay (no jitter) for "constant" backoff.
+func backoffDuration(policy RetryPolicy, attempt int) time.Duration {
+	if policy.Backoff != "exponential" {
+		return policy.BackoffInitial
+	}
+	delay := policy.BackoffInitial * time.Duration(1<<uint(attempt))
+	if policy.BackoffMax > 0 && delay > policy.BackoffMax {
+		delay = policy.BackoffMax
+	}
+	jitter := 1 + (rand.Float64() - 0.5)
+	return time.Duration(float64(delay) * jitter)
+}
+
+// Scheduler executes every task in a TaskDAG concurrently: a task is
+// launched as soon as all of its predecessors have produced a Result,
+// rather than the graph being walked in a single serial topological order.
+type Scheduler struct {
+	dag *TaskDAG
+
+	mu        sync.Mutex
+	results   map[int64]Result
+	done      map[int64]chan struct{}
+	idByDotID map[string]int64
+
+	jobID         int32
+	pipelineRunID int64
+	recorder      RunRecorder
+	persistErrs   []error
+}
+
+// VarsSetter is implemented by tasks (like HTTPTask) that consume named
+// upstream results beyond their direct graph predecessor, via the `inputs`
+// DOT attribute. The Scheduler calls SetVars once all of the task's
+// declared inputs have resolved, before calling Run.
+type VarsSetter interface {
+	SetVars(vars map[string]interface{})
+}
+
+// Defaulter is implemented by tasks (like JSONParseTask and HTTPTask) that
+// need to compile something fallible — a JMESPath expression, a Go
+// template — once, so a malformed spec is caught at job-load time rather
+// than on the job's first run. NewScheduler calls SetDefaults once per
+// task, before Run is ever invoked.
+type Defaulter interface {
+	SetDefaults(inputValues map[string]string, g *TaskDAG, self *taskDAGNode) error
+}
+
+// RunRecorder persists a TaskRun after every Task.Run invocation, so that
+// `SELECT * FROM pipeline_task_runs WHERE pipeline_run_id=?` reflects what
+// actually happened without the caller having to grep logs.
+type RunRecorder interface {
+	RecordTaskRun(run TaskRun) error
+}
+
+// SchedulerOption configures optional Scheduler behavior.
+type SchedulerOption func(*Scheduler)
+
+// WithObservability attaches a Run/TaskRun recorder to the Scheduler,
+// tagging every persisted TaskRun with the given pipeline run ID and every
+// Prometheus metric with the given job ID.
+func WithObservability(jobID int32, pipelineRunID int64, recorder RunRecorder) SchedulerOption {
+	return func(s *Scheduler) {
+		s.jobID = jobID
+		s.pipelineRunID = pipelineRunID
+		s.recorder = recorder
+	}
+}
+
+// NewScheduler prepares a Scheduler for the given TaskDAG. It returns an
+// error if the graph, together with every task's `inputs`-declared wait
+// dependencies, contains a cycle, since a fan-out execution can never
+// terminate in that case.
+func NewScheduler(dag *TaskDAG, opts ...SchedulerOption) (*Scheduler, error) {
+	if dag.HasCycles() {
+		return nil, errors.New("Scheduler: task graph contains a cycle")
+	}
+
+	s := &Scheduler{
+		dag:       dag,
+		results:   make(map[int64]Result),
+		done:      make(map[int64]chan struct{}),
+		idByDotID: make(map[string]int64),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	iter := dag.Nodes()
+	for iter.Next() {
+		node := iter.Node().(*taskDAGNode)
+		s.done[node.ID()] = make(chan struct{})
+		s.idByDotID[node.DOTID()] = node.ID()
+	}
+
+	if err := validateNoCycles(dag, s.idByDotID); err != nil {
+		return nil, err
+	}
+
+	iter = dag.Nodes()
+	for iter.Next() {
+		node := iter.Node().(*taskDAGNode)
+		if defaulter, ok := node.Task.(Defaulter); ok {
+			if err := defaulter.SetDefaults(nil, dag, node); err != nil {
+				return nil, errors.Wrapf(err, "task %q", node.DOTID())
+			}
+		}
+	}
+	return s, nil
+}
+
+// validateNoCycles checks that the union of graph edges and `inputs`-
+// declared dependencies contains no cycle. dag.HasCycles() only sees
+// graph edges; the `inputs` DOT attribute creates an additional wait
+// dependency in resolveNamedInputs that the graph itself doesn't know
+// about, so two VarsSetter tasks naming each other in `inputs` (or a task
+// naming itself) would otherwise deadlock Run forever, with nothing but
+// the caller's ctx to ever escape it.
+func validateNoCycles(dag *TaskDAG, idByDotID map[string]int64) error {
+	dependents := make(map[int64][]int64)
+
+	iter := dag.Nodes()
+	for iter.Next() {
+		node := iter.Node().(*taskDAGNode)
+
+		preds := dag.To(node.ID())
+		for preds.Next() {
+			predID := preds.Node().ID()
+			dependents[predID] = append(dependents[predID], node.ID())
+		}
+
+		for _, name := range inputsAttr(node.Attributes()) {
+			if inputID, ok := idByDotID[name]; ok {
+				dependents[inputID] = append(dependents[inputID], node.ID())
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[int64]int, len(dependents))
+
+	var visit func(id int64) bool
+	visit = func(id int64) bool {
+		switch state[id] {
+		case visiting:
+			return true
+		case done:
+			return false
+		}
+		state[id] = visiting
+		for _, next := range dependents[id] {
+			if visit(next) {
+				return true
+			}
+		}
+		state[id] = done
+		return false
+	}
+
+	iter = dag.Nodes()
+	for iter.Next() {
+		id := iter.Node().ID()
+		if state[id] == unvisited && visit(id) {
+			return errors.New("Scheduler: task graph contains a cycle once `inputs` dependencies are taken into account")
+		}
+	}
+	return nil
+}
+
+// Run executes the DAG to completion and returns the Result of every task,
+// keyed by its node ID. Each task is started as soon as its predecessors'
+// Results are available; independent branches of the graph run in parallel.
+func (s *Scheduler) Run(ctx context.Context) (map[int64]Result, error) {
+	var wg sync.WaitGroup
+
+	iter := s.dag.Nodes()
+	for iter.Next() {
+		node := iter.Node().(*taskDAGNode)
+		wg.Add(1)
+		go func(node *taskDAGNode) {
+			defer wg.Done()
+			defer close(s.done[node.ID()])
+			s.runTask(ctx, node)
+		}(node)
+	}
+	wg.Wait()
+
+	select {
+	case <-ctx.Done():
+		return s.snapshot(), ctx.Err()
+	default:
+		return s.snapshot(), nil
+	}
+}
+
+func (s *Scheduler) snapshot() map[int64]Result {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[int64]Result, len(s.results))
+	for id, result := range s.results {
+		out[id] = result
+	}
+	return out
+}
+
+// runTask blocks until every predecessor of `node` has finished, gathers
+// their Results in edge order, and runs `node`'s task with retry/backoff.
+func (s *Scheduler) runTask(ctx context.Context, node *taskDAGNode) {
+	preds := s.dag.To(node.ID())
+	var inputs []Result
+	for preds.Next() {
+		pred := preds.Node()
+		select {
+		case <-s.done[pred.ID()]:
+		case <-ctx.Done():
+			s.setResult(node.ID(), Result{Error: ctx.Err()})
+			return
+		}
+		s.mu.Lock()
+		inputs = append(inputs, s.results[pred.ID()])
+		s.mu.Unlock()
+	}
+
+	// node.Task is the concretely-typed Task instance the DAG constructed
+	// from this node's DOT attributes (a *JSONParseTask with its Path
+	// already populated, an *HTTPTask with its URL already populated, and
+	// so on). We run that instance directly, rather than running `node`
+	// itself as the Task: node only promotes Task's own methods, so a type
+	// assertion against node (e.g. node.(VarsSetter)) can never see
+	// interfaces implemented by the concrete task it wraps.
+	task := node.Task
+	if setter, ok := task.(VarsSetter); ok {
+		vars, err := s.resolveNamedInputs(ctx, inputsAttr(node.Attributes()))
+		if err != nil {
+			s.setResult(node.ID(), Result{Error: err})
+			return
+		}
+		setter.SetVars(vars)
+	}
+
+	policy := retryPolicyFromAttrs(node.Attributes())
+	result := s.runWithRetry(ctx, task, node.DOTID(), inputs, policy)
+	s.setResult(node.ID(), result)
+}
+
+// inputsAttr reads the comma-separated `inputs` DOT attribute, e.g.
+// `inputs="ds1,ds2"`, declaring which upstream results (by DOT ID) a
+// VarsSetter task wants exposed regardless of whether a graph edge
+// already connects them.
+func inputsAttr(attrs []encoding.Attribute) []string {
+	for _, attr := range attrs {
+		if attr.Key != "inputs" {
+			continue
+		}
+		var names []string
+		for _, name := range strings.Split(attr.Value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// resolveNamedInputs waits for each declared upstream task to finish and
+// returns a map of its DOT ID to its Result.Value.
+func (s *Scheduler) resolveNamedInputs(ctx context.Context, names []string) (map[string]interface{}, error) {
+	vars := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		id, ok := s.idByDotID[name]
+		if !ok {
+			return nil, errors.Errorf("no such task %q declared in inputs", name)
+		}
+		select {
+		case <-s.done[id]:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		s.mu.Lock()
+		result := s.results[id]
+		s.mu.Unlock()
+		if result.Error != nil {
+			return nil, errors.Wrapf(result.Error, "upstream task %q failed", name)
+		}
+		vars[name] = result.Value
+	}
+	return vars, nil
+}
+
+func (s *Scheduler) runWithRetry(ctx context.Context, task Task, dotID string, inputs []Result, policy RetryPolicy) Result {
+	var result Result
+	for attempt := 0; attempt <= policy.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoffDuration(policy, attempt-1)):
+			case <-ctx.Done():
+				return Result{Error: ctx.Err()}
+			}
+		}
+
+		runCtx := ctx
+		var cancel context.CancelFunc
+		if policy.Timeout > 0 {
+			runCtx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		}
+		result = s.runTaskObserved(runCtx, task, dotID, inputs)
+		if cancel != nil {
+			cancel()
+		}
+
+		if result.Error == nil || ctx.Err() != nil {
+			return result
+		}
+	}
+	return result
+}
+
+// runTaskObserved runs task.Run in a goroutine (so a context timeout or
+// cancellation can return control to the caller even though the Task
+// interface has no context-aware Run variant), and wraps the call with an
+// OpenTelemetry span, Prometheus metrics, and, if a RunRecorder was
+// configured via WithObservability, a persisted TaskRun.
+func (s *Scheduler) runTaskObserved(ctx context.Context, task Task, dotID string, inputs []Result) Result {
+	ctx, span := otel.Tracer("pipeline").Start(ctx, dotID)
+	defer span.End()
+
+	taskType := fmt.Sprintf("%T", task)
+	span.SetAttributes(
+		attribute.String("task_type", taskType),
+		attribute.String("dot_id", dotID),
+	)
+
+	startedAt := time.Now()
+	resultCh := make(chan Result, 1)
+	go func() { resultCh <- task.Run(inputs) }()
+
+	var result Result
+	select {
+	case result = <-resultCh:
+	case <-ctx.Done():
+		result = Result{Error: ctx.Err()}
+	}
+	finishedAt := time.Now()
+
+	jobIDLabel := strconv.Itoa(int(s.jobID))
+	promTaskDuration.WithLabelValues(taskType, dotID, jobIDLabel).Observe(finishedAt.Sub(startedAt).Seconds())
+	if result.Error != nil {
+		promTaskErrors.WithLabelValues(taskType, dotID, jobIDLabel).Inc()
+		span.RecordError(result.Error)
+	}
+
+	if s.recorder != nil {
+		run := NewTaskRun(s.pipelineRunID, dotID, inputs, result, startedAt, finishedAt)
+		if err := s.recorder.RecordTaskRun(run); err != nil {
+			span.RecordError(err)
+			s.mu.Lock()
+			s.persistErrs = append(s.persistErrs, errors.Wrapf(err, "failed to record TaskRun for %q", dotID))
+			s.mu.Unlock()
+		}
+	}
+
+	return result
+}
+
+// PersistErrors returns every error encountered while persisting a TaskRun
+// via the configured RunRecorder. These are distinct from the tasks' own
+// Result.Error values: a persistence failure doesn't fail the task, but
+// must not be silently dropped either, since it means the debug API won't
+// have a complete picture of this run.
+func (s *Scheduler) PersistErrors() []error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]error(nil), s.persistErrs...)
+}
+
+func (s *Scheduler) setResult(id int64, result Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results[id] = result
+}
@@ -0,0 +1,143 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/graph/encoding"
+)
+
+// TestScheduler_RunsIndependentBranchesConcurrently uses two local
+// httptest servers that each sleep for a fixed delay before responding, so
+// the assertion on elapsed time actually distinguishes concurrent
+// execution from serial execution, without depending on a real network
+// call or a context timeout to make the point.
+func TestScheduler_RunsIndependentBranchesConcurrently(t *testing.T) {
+	const branchDelay = 200 * time.Millisecond
+
+	ds1 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(branchDelay)
+		w.Write([]byte(`{"price":"10"}`))
+	}))
+	defer ds1.Close()
+
+	ds2 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(branchDelay)
+		w.Write([]byte(`{"price":"20"}`))
+	}))
+	defer ds2.Close()
+
+	dot := fmt.Sprintf(`
+        ds1       [type=http method=GET url=%q requestData="{}"];
+        ds1_parse [type=jsonparse path="price"];
+        ds1 -> ds1_parse;
+
+        ds2       [type=http method=GET url=%q requestData="{}"];
+        ds2_parse [type=jsonparse path="price"];
+        ds2 -> ds2_parse;
+
+        answer [type=median];
+        ds1_parse -> answer;
+        ds2_parse -> answer;
+    `, ds1.URL, ds2.URL)
+
+	g := NewTaskDAG()
+	require.NoError(t, g.UnmarshalText([]byte(dot)))
+
+	s, err := NewScheduler(g)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	startedAt := time.Now()
+	results, err := s.Run(ctx)
+	elapsed := time.Since(startedAt)
+	require.NoError(t, err)
+
+	// Serial execution of two branches that each sleep branchDelay would
+	// take at least 2*branchDelay; running them concurrently should take
+	// closer to one.
+	require.Less(t, elapsed, 2*branchDelay)
+
+	ds1Result := results[s.idByDotID["ds1_parse"]]
+	require.NoError(t, ds1Result.Error)
+	require.Equal(t, "10", ds1Result.Value)
+
+	ds2Result := results[s.idByDotID["ds2_parse"]]
+	require.NoError(t, ds2Result.Error)
+	require.Equal(t, "20", ds2Result.Value)
+
+	answerResult := results[s.idByDotID["answer"]]
+	require.NoError(t, answerResult.Error)
+	require.Equal(t, "15", answerResult.Value.(decimal.Decimal).String())
+}
+
+func TestScheduler_RejectsCyclicGraphs(t *testing.T) {
+	g := NewTaskDAG()
+	err := g.UnmarshalText([]byte(`
+        digraph {
+            a [type=bridge];
+            b [type=multiply times=1.23];
+            a -> b -> a;
+        }
+    `))
+	require.NoError(t, err)
+	require.True(t, g.HasCycles())
+
+	_, err = NewScheduler(g)
+	require.Error(t, err)
+}
+
+// TestScheduler_RejectsInputsOnlyCycles covers a cycle that only exists
+// via the `inputs` attribute, with no corresponding graph edge: dag.HasCycles()
+// alone can't see it, and NewScheduler rejects it for every task type, not
+// only for the VarsSetter tasks that Run would deadlock on.
+func TestScheduler_RejectsInputsOnlyCycles(t *testing.T) {
+	g := NewTaskDAG()
+	err := g.UnmarshalText([]byte(`
+        a [type=jsonparse path="x" inputs="b"];
+        b [type=jsonparse path="x" inputs="a"];
+    `))
+	require.NoError(t, err)
+	require.False(t, g.HasCycles())
+
+	_, err = NewScheduler(g)
+	require.Error(t, err)
+}
+
+func TestRetryPolicyFromAttrs(t *testing.T) {
+	attrs := []encoding.Attribute{
+		{Key: "maxRetries", Value: "3"},
+		{Key: "backoff", Value: "exponential"},
+		{Key: "backoffInitial", Value: "500ms"},
+		{Key: "backoffMax", Value: "30s"},
+		{Key: "timeout", Value: "10s"},
+	}
+	policy := retryPolicyFromAttrs(attrs)
+	require.Equal(t, 3, policy.MaxRetries)
+	require.Equal(t, "exponential", policy.Backoff)
+	require.Equal(t, 500*time.Millisecond, policy.BackoffInitial)
+	require.Equal(t, 30*time.Second, policy.BackoffMax)
+	require.Equal(t, 10*time.Second, policy.Timeout)
+}
+
+func TestBackoffDuration_Exponential(t *testing.T) {
+	policy := RetryPolicy{
+		Backoff:        "exponential",
+		BackoffInitial: 100 * time.Millisecond,
+		BackoffMax:     1 * time.Second,
+	}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		d := backoffDuration(policy, attempt)
+		require.GreaterOrEqual(t, d, time.Duration(0))
+		require.LessOrEqual(t, d, policy.BackoffMax+policy.BackoffMax/2)
+	}
+}
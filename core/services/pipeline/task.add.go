@@ -0,0 +1,29 @@
+package pipeline
+
+import (
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// AddTask adds a constant to its single input, e.g. `type=add by=-1.5` to
+// apply a fixed offset to a price feed.
+type AddTask struct {
+	BaseTask
+	By decimal.Decimal `json:"by"`
+}
+
+var _ Task = (*AddTask)(nil)
+
+func (t *AddTask) Run(inputs []Result) Result {
+	if len(inputs) != 1 {
+		return Result{Error: errors.Wrapf(ErrWrongInputCardinality, "AddTask requires a single input")}
+	} else if inputs[0].Error != nil {
+		return Result{Error: inputs[0].Error}
+	}
+
+	value, err := coerceToDecimal(inputs[0].Value)
+	if err != nil {
+		return Result{Error: err}
+	}
+	return Result{Value: value.Add(t.By)}
+}
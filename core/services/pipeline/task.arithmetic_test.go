@@ -0,0 +1,244 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func mustDecimal(t *testing.T, arg string) decimal.Decimal {
+	ret, err := decimal.NewFromString(arg)
+	require.NoError(t, err)
+	return ret
+}
+
+func TestAddTask_Happy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input interface{}
+		by    decimal.Decimal
+		want  decimal.Decimal
+	}{
+		{"string", "1.23", mustDecimal(t, "100"), mustDecimal(t, "101.23")},
+		{"int", int(2), mustDecimal(t, "100"), mustDecimal(t, "102")},
+		{"float64", float64(1.23), mustDecimal(t, "-5"), mustDecimal(t, "-3.77")},
+		{"decimal", mustDecimal(t, "1.23"), mustDecimal(t, "0"), mustDecimal(t, "1.23")},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			task := AddTask{By: test.by}
+			result := task.Run([]Result{{Value: test.input}})
+			require.NoError(t, result.Error)
+			require.Equal(t, test.want.String(), result.Value.(decimal.Decimal).String())
+		})
+	}
+}
+
+func TestAddTask_Unhappy(t *testing.T) {
+	tests := []struct {
+		name  string
+		input interface{}
+	}{
+		{"map", map[string]interface{}{"chain": "link"}},
+		{"slice", []interface{}{"chain", "link"}},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			task := AddTask{By: mustDecimal(t, "1")}
+			result := task.Run([]Result{{Value: test.input}})
+			require.Error(t, result.Error)
+		})
+	}
+}
+
+func TestSubtractTask_Happy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input interface{}
+		by    decimal.Decimal
+		want  decimal.Decimal
+	}{
+		{"string", "1.23", mustDecimal(t, "1"), mustDecimal(t, "0.23")},
+		{"int", int(10), mustDecimal(t, "4"), mustDecimal(t, "6")},
+		{"float64", float64(1.23), mustDecimal(t, "-5"), mustDecimal(t, "6.23")},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			task := SubtractTask{By: test.by}
+			result := task.Run([]Result{{Value: test.input}})
+			require.NoError(t, result.Error)
+			require.Equal(t, test.want.String(), result.Value.(decimal.Decimal).String())
+		})
+	}
+}
+
+func TestSubtractTask_Unhappy(t *testing.T) {
+	tests := []struct {
+		name  string
+		input interface{}
+	}{
+		{"map", map[string]interface{}{"chain": "link"}},
+		{"slice", []interface{}{"chain", "link"}},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			task := SubtractTask{By: mustDecimal(t, "1")}
+			result := task.Run([]Result{{Value: test.input}})
+			require.Error(t, result.Error)
+		})
+	}
+}
+
+func TestDivideTask_Happy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     interface{}
+		by        decimal.Decimal
+		precision int32
+		want      string
+	}{
+		{"wei to ether", "1000000000000000000", mustDecimal(t, "1e18"), 18, "1"},
+		{"string, default precision", "10", mustDecimal(t, "3"), 0, "3.3333333333333333"},
+		{"int", int(10), mustDecimal(t, "4"), 2, "2.5"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			task := DivideTask{By: test.by, Precision: test.precision}
+			result := task.Run([]Result{{Value: test.input}})
+			require.NoError(t, result.Error)
+			require.Equal(t, test.want, result.Value.(decimal.Decimal).String())
+		})
+	}
+}
+
+func TestDivideTask_Unhappy(t *testing.T) {
+	tests := []struct {
+		name  string
+		input interface{}
+		by    decimal.Decimal
+	}{
+		{"map", map[string]interface{}{"chain": "link"}, mustDecimal(t, "1")},
+		{"slice", []interface{}{"chain", "link"}, mustDecimal(t, "1")},
+		{"divide by zero", "10", decimal.Zero},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			task := DivideTask{By: test.by}
+			result := task.Run([]Result{{Value: test.input}})
+			require.Error(t, result.Error)
+		})
+	}
+}
+
+func TestPowerTask_Happy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		exponent decimal.Decimal
+		want     decimal.Decimal
+	}{
+		{"string, squared", "3", mustDecimal(t, "2"), mustDecimal(t, "9")},
+		{"int, cubed", int(2), mustDecimal(t, "3"), mustDecimal(t, "8")},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			task := PowerTask{Exponent: test.exponent}
+			result := task.Run([]Result{{Value: test.input}})
+			require.NoError(t, result.Error)
+			require.Equal(t, test.want.String(), result.Value.(decimal.Decimal).String())
+		})
+	}
+}
+
+func TestPowerTask_Unhappy(t *testing.T) {
+	tests := []struct {
+		name  string
+		input interface{}
+	}{
+		{"map", map[string]interface{}{"chain": "link"}},
+		{"slice", []interface{}{"chain", "link"}},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			task := PowerTask{Exponent: mustDecimal(t, "2")}
+			result := task.Run([]Result{{Value: test.input}})
+			require.Error(t, result.Error)
+		})
+	}
+}
+
+func TestMeanTask(t *testing.T) {
+	task := MeanTask{}
+	result := task.Run([]Result{{Value: "1"}, {Value: "2"}, {Value: "3"}})
+	require.NoError(t, result.Error)
+	require.Equal(t, "2", result.Value.(decimal.Decimal).String())
+}
+
+func TestMeanTask_Unhappy(t *testing.T) {
+	task := MeanTask{}
+	result := task.Run([]Result{{Value: map[string]interface{}{"chain": "link"}}})
+	require.Error(t, result.Error)
+}
+
+func TestModeTask(t *testing.T) {
+	t.Run("single mode", func(t *testing.T) {
+		task := ModeTask{}
+		result := task.Run([]Result{{Value: "1"}, {Value: "2"}, {Value: "2"}})
+		require.NoError(t, result.Error)
+		require.Equal(t, "2", result.Value.(decimal.Decimal).String())
+	})
+
+	t.Run("tie returns the smallest tied value", func(t *testing.T) {
+		task := ModeTask{}
+		result := task.Run([]Result{{Value: "2"}, {Value: "1"}})
+		require.NoError(t, result.Error)
+		require.Equal(t, "1", result.Value.(decimal.Decimal).String())
+	})
+}
+
+func TestQuantileTask(t *testing.T) {
+	t.Run("interpolates between straddling ranks", func(t *testing.T) {
+		task := QuantileTask{Q: mustDecimal(t, "0.75")}
+		result := task.Run([]Result{{Value: "1"}, {Value: "2"}, {Value: "3"}, {Value: "4"}})
+		require.NoError(t, result.Error)
+		require.Equal(t, "3.25", result.Value.(decimal.Decimal).String())
+	})
+
+	t.Run("q=0.5 over even N agrees with MedianTask", func(t *testing.T) {
+		task := QuantileTask{Q: mustDecimal(t, "0.5")}
+		result := task.Run([]Result{{Value: "1"}, {Value: "2"}, {Value: "3"}, {Value: "4"}})
+		require.NoError(t, result.Error)
+		require.Equal(t, "2.5", result.Value.(decimal.Decimal).String())
+	})
+}
+
+func TestQuantileTask_Unhappy(t *testing.T) {
+	task := QuantileTask{Q: mustDecimal(t, "1.5")}
+	result := task.Run([]Result{{Value: "1"}})
+	require.Error(t, result.Error)
+}
@@ -0,0 +1,45 @@
+package pipeline
+
+import (
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// coerceToDecimal converts a JSON-decoded or upstream task value into a
+// decimal.Decimal, accepting the same set of input types as MultiplyTask
+// (string, the signed/unsigned integer kinds, float32/float64, and
+// decimal.Decimal itself) and rejecting maps and slices.
+func coerceToDecimal(input interface{}) (decimal.Decimal, error) {
+	switch v := input.(type) {
+	case decimal.Decimal:
+		return v, nil
+	case string:
+		return decimal.NewFromString(v)
+	case int:
+		return decimal.New(int64(v), 0), nil
+	case int8:
+		return decimal.New(int64(v), 0), nil
+	case int16:
+		return decimal.New(int64(v), 0), nil
+	case int32:
+		return decimal.New(int64(v), 0), nil
+	case int64:
+		return decimal.New(v, 0), nil
+	case uint:
+		return decimal.New(int64(v), 0), nil
+	case uint8:
+		return decimal.New(int64(v), 0), nil
+	case uint16:
+		return decimal.New(int64(v), 0), nil
+	case uint32:
+		return decimal.New(int64(v), 0), nil
+	case uint64:
+		return decimal.New(int64(v), 0), nil
+	case float32:
+		return decimal.NewFromFloat32(v), nil
+	case float64:
+		return decimal.NewFromFloat(v), nil
+	default:
+		return decimal.Decimal{}, errors.Errorf("cannot convert %T to decimal.Decimal", input)
+	}
+}
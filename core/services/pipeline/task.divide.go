@@ -0,0 +1,44 @@
+package pipeline
+
+import (
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// defaultDividePrecision matches decimal.DivisionPrecision, the precision
+// decimal.Decimal's Div uses, and applies when a DivideTask spec doesn't
+// set one explicitly.
+const defaultDividePrecision = int32(16)
+
+// DivideTask divides its single input `by` a constant, e.g.
+// `type=divide by=1e18 precision=18` to convert a wei amount to ether.
+type DivideTask struct {
+	BaseTask
+	By        decimal.Decimal `json:"by"`
+	Precision int32           `json:"precision"`
+}
+
+var _ Task = (*DivideTask)(nil)
+
+func (t *DivideTask) Run(inputs []Result) Result {
+	if len(inputs) != 1 {
+		return Result{Error: errors.Wrapf(ErrWrongInputCardinality, "DivideTask requires a single input")}
+	} else if inputs[0].Error != nil {
+		return Result{Error: inputs[0].Error}
+	}
+
+	if t.By.IsZero() {
+		return Result{Error: errors.New("DivideTask: cannot divide by zero")}
+	}
+
+	value, err := coerceToDecimal(inputs[0].Value)
+	if err != nil {
+		return Result{Error: err}
+	}
+
+	precision := t.Precision
+	if precision == 0 {
+		precision = defaultDividePrecision
+	}
+	return Result{Value: value.DivRound(t.By, precision)}
+}
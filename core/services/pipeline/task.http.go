@@ -0,0 +1,192 @@
+package pipeline
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// HTTPTask makes an HTTP request and returns the response body. The URL,
+// RequestData, and Headers may each reference upstream results by DOT ID
+// using Go template syntax, e.g. `{{ .ds1 }}` or `{{ .ds1.price }}`; the
+// `inputs` DOT attribute declares which upstream tasks are exposed this
+// way, independent of which task feeds HTTPTask's primary graph input.
+// In the URL, template actions take effect only in the query string: the
+// URL template is compiled from URL.String(), which percent-escapes `{`,
+// `}` and spaces in the path, so a `{{ ... }}` in the path is sent as
+// escaped literal text and is not rendered.
+type HTTPTask struct {
+	BaseTask
+	Method      string            `json:"method"`
+	URL         models.WebURL     `json:"url"`
+	RequestData HttpRequestData   `json:"requestData"`
+	Headers     map[string]string `json:"headers"`
+
+	urlTemplate     *template.Template
+	requestTemplate *template.Template
+	headerTemplates map[string]*template.Template
+
+	vars map[string]interface{}
+}
+
+var _ Task = (*HTTPTask)(nil)
+var _ VarsSetter = (*HTTPTask)(nil)
+
+// SetDefaults compiles the task's templates once, at job-load time, so a
+// malformed `{{ ... }}` expression is caught as a spec error rather than
+// surfacing on the job's first run.
+func (t *HTTPTask) SetDefaults(inputValues map[string]string, g *TaskDAG, self *taskDAGNode) error {
+	urlTemplate, err := compileHTTPTemplate("url", t.URL.String())
+	if err != nil {
+		return err
+	}
+
+	requestDataJSON, err := json.Marshal(t.RequestData)
+	if err != nil {
+		return errors.Wrap(err, "HTTPTask: could not marshal requestData")
+	}
+	requestTemplate, err := compileHTTPTemplate("requestData", string(requestDataJSON))
+	if err != nil {
+		return err
+	}
+
+	headerTemplates := make(map[string]*template.Template, len(t.Headers))
+	for key, value := range t.Headers {
+		tmpl, err := compileHTTPTemplate("headers."+key, value)
+		if err != nil {
+			return err
+		}
+		headerTemplates[key] = tmpl
+	}
+
+	t.urlTemplate = urlTemplate
+	t.requestTemplate = requestTemplate
+	t.headerTemplates = headerTemplates
+	return nil
+}
+
+// SetVars is called by the Scheduler once every task named in `inputs` has
+// resolved, so that their results are available to this task's templates.
+func (t *HTTPTask) SetVars(vars map[string]interface{}) {
+	t.vars = vars
+}
+
+func compileHTTPTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return nil, errors.Wrapf(err, "HTTPTask: could not parse %s template", name)
+	}
+	return tmpl, nil
+}
+
+func (t *HTTPTask) render(tmpl *template.Template) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, t.vars); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Run renders this task's URL/requestData/header templates and performs
+// the HTTP request. SetDefaults compiles those templates once at
+// job-load time, but Run falls back to compiling them here if SetDefaults
+// was never called (e.g. a task constructed directly in tests, or by a
+// caller that doesn't go through a job loader) — the same fallback
+// JSONParseTask.runExpression uses for a nil compiled JMESPath.
+func (t *HTTPTask) Run(inputs []Result) Result {
+	urlTemplate := t.urlTemplate
+	if urlTemplate == nil {
+		var err error
+		urlTemplate, err = compileHTTPTemplate("url", t.URL.String())
+		if err != nil {
+			return Result{Error: err}
+		}
+	}
+	url, err := t.render(urlTemplate)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "HTTPTask: could not render url template")}
+	}
+
+	requestDataJSON, err := json.Marshal(t.RequestData)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "HTTPTask: could not marshal requestData")}
+	}
+	requestTemplate := t.requestTemplate
+	if requestTemplate == nil {
+		requestTemplate, err = compileHTTPTemplate("requestData", string(requestDataJSON))
+		if err != nil {
+			return Result{Error: err}
+		}
+	}
+	rendered, err := t.render(requestTemplate)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "HTTPTask: could not render requestData template")}
+	}
+	body := []byte(rendered)
+
+	headerTemplates := t.headerTemplates
+	if headerTemplates == nil && len(t.Headers) > 0 {
+		headerTemplates = make(map[string]*template.Template, len(t.Headers))
+		for key, value := range t.Headers {
+			tmpl, err := compileHTTPTemplate("headers."+key, value)
+			if err != nil {
+				return Result{Error: err}
+			}
+			headerTemplates[key] = tmpl
+		}
+	}
+
+	request, err := http.NewRequest(t.Method, url, bytes.NewReader(body))
+	if err != nil {
+		return Result{Error: err}
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for key, tmpl := range headerTemplates {
+		value, err := t.render(tmpl)
+		if err != nil {
+			return Result{Error: errors.Wrapf(err, "HTTPTask: could not render %s header template", key)}
+		}
+		request.Header.Set(key, value)
+	}
+
+	httpClient := http.Client{Timeout: 30 * time.Second}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return Result{Error: err}
+	}
+	defer response.Body.Close()
+
+	responseBytes, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return Result{Error: err}
+	}
+	if response.StatusCode >= 400 {
+		return Result{Error: errors.Errorf("HTTPTask: %v returned HTTP status %v", url, response.StatusCode)}
+	}
+	return Result{Value: responseBytes}
+}
+
+// HttpRequestData is the JSON-decoded body of an HTTP request, stored as a
+// plain map so it can be templated and re-marshaled.
+type HttpRequestData map[string]interface{}
+
+func (h *HttpRequestData) Scan(value interface{}) error {
+	return json.Unmarshal(value.([]byte), h)
+}
+
+func (h HttpRequestData) Value() (driver.Value, error) {
+	return json.Marshal(h)
+}
+
+func (h HttpRequestData) String() string {
+	return fmt.Sprintf("%v", map[string]interface{}(h))
+}
@@ -0,0 +1,109 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+func TestHTTPTask_TemplatesURLAndRequestData(t *testing.T) {
+	var gotBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&gotBody))
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	task := HTTPTask{
+		Method:      "POST",
+		URL:         webURL(t, u.String()),
+		RequestData: HttpRequestData{"assetId": "{{ .ds1_asset_id }}"},
+	}
+	require.NoError(t, task.SetDefaults(nil, nil, nil))
+	task.SetVars(map[string]interface{}{"ds1_asset_id": "ETH-USD"})
+
+	result := task.Run(nil)
+	require.NoError(t, result.Error)
+	require.Equal(t, "ETH-USD", gotBody["assetId"])
+}
+
+func TestHTTPTask_TemplateParseErrorIsCaughtAtSetDefaults(t *testing.T) {
+	task := HTTPTask{
+		Method:      "GET",
+		URL:         webURL(t, "https://example.com"),
+		RequestData: HttpRequestData{"bad": "{{ .unterminated"},
+	}
+	err := task.SetDefaults(nil, nil, nil)
+	require.Error(t, err)
+}
+
+func webURL(t *testing.T, raw string) models.WebURL {
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+	return models.WebURL(*u)
+}
+
+// TestWebURL_HoldsATemplateString confirms where a models.WebURL can hold
+// a `{{ ... }}` template string: String() keeps the query string verbatim,
+// but percent-escapes `{`, `}` and spaces in the path, so a template placed
+// in the path does not survive.
+func TestWebURL_HoldsATemplateString(t *testing.T) {
+	raw := "https://example.com/price?asset={{ .ds3_id }}"
+	u := webURL(t, raw)
+	require.Equal(t, raw, u.String())
+
+	inPath := webURL(t, "https://example.com/{{ .ds3_id }}")
+	require.Equal(t, "https://example.com/%7B%7B%20.ds3_id%20%7D%7D", inPath.String())
+}
+
+// TestHTTPTask_EndToEndThroughScheduler proves the `inputs` DOT attribute
+// actually reaches HTTPTask.SetVars, and that the URL/requestData
+// templates actually get rendered, when the task is run as part of a real
+// TaskDAG via the Scheduler rather than constructed and run by hand.
+func TestHTTPTask_EndToEndThroughScheduler(t *testing.T) {
+	var gotBody map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/asset", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"assetId":"ETH-USD"}`))
+	})
+	mux.HandleFunc("/submit", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&gotBody))
+		w.Write([]byte(`{"ok":true}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	dot := fmt.Sprintf(`
+        ds1    [type=http method=GET url=%q requestData="{}"];
+        ds1_id [type=jsonparse path="assetId"];
+        ds1 -> ds1_id;
+
+        ds2 [type=http method=POST url=%q inputs="ds1_id"
+             requestData="{\"assetId\": \"{{ .ds1_id }}\"}"];
+    `, server.URL+"/asset", server.URL+"/submit")
+
+	g := NewTaskDAG()
+	require.NoError(t, g.UnmarshalText([]byte(dot)))
+
+	s, err := NewScheduler(g)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = s.Run(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "ETH-USD", gotBody["assetId"])
+}
@@ -6,16 +6,46 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/jmespath/go-jmespath"
 	"github.com/pkg/errors"
 )
 
+// JSONParseTask extracts a value from a JSON document, either by walking
+// the legacy `Path []string` (a flat list of map keys and array indices,
+// kept for specs that haven't been migrated) or, if `Expression` is set,
+// by evaluating a compiled JMESPath expression against the decoded JSON.
+// The two are deliberately not unified: the legacy walker decides at run
+// time whether a numeric element indexes an array or names a map key, and
+// fails on a missing intermediate element, whereas a compiled JMESPath
+// expression fixes that choice up front and returns null on any miss, so
+// compiling a legacy Path down to an expression would silently change its
+// behavior for those cases.
 type JSONParseTask struct {
 	BaseTask
-	Path JSONPath `json:"path"`
+	Path       JSONPath `json:"path"`
+	Expression string   `json:"expression"`
+
+	compiled *jmespath.JMESPath
 }
 
 var _ Task = (*JSONParseTask)(nil)
 
+// SetDefaults compiles the task's JMESPath expression once, at job-load
+// time, so that a malformed expression is surfaced as a spec error rather
+// than a per-run failure.
+func (t *JSONParseTask) SetDefaults(inputValues map[string]string, g *TaskDAG, self *taskDAGNode) error {
+	if t.Expression == "" {
+		return nil
+	}
+
+	compiled, err := jmespath.Compile(t.Expression)
+	if err != nil {
+		return errors.Wrapf(err, "JSONParseTask: could not compile expression %q", t.Expression)
+	}
+	t.compiled = compiled
+	return nil
+}
+
 func (t *JSONParseTask) Run(inputs []Result) Result {
 	if len(inputs) != 1 {
 		return Result{Error: errors.Wrapf(ErrWrongInputCardinality, "JSONParseTask requires a single input")}
@@ -34,11 +64,43 @@ func (t *JSONParseTask) Run(inputs []Result) Result {
 	}
 
 	var decoded interface{}
-	err = json.Unmarshal(bs, &decoded)
+	if err := json.Unmarshal(bs, &decoded); err != nil {
+		return Result{Error: err}
+	}
+
+	if t.Expression != "" {
+		return t.runExpression(decoded)
+	}
+	return t.runPath(decoded)
+}
+
+// runExpression evaluates the task's JMESPath expression, compiling it on
+// the fly if SetDefaults wasn't called ahead of time. A missing leaf key
+// is not fatal — JMESPath's Search returns a nil result rather than an
+// error in that case — matching the null-tolerant behavior of runPath.
+func (t *JSONParseTask) runExpression(decoded interface{}) Result {
+	compiled := t.compiled
+	if compiled == nil {
+		var err error
+		compiled, err = jmespath.Compile(t.Expression)
+		if err != nil {
+			return Result{Error: errors.Wrapf(err, "JSONParseTask: could not compile expression %q", t.Expression)}
+		}
+	}
+
+	result, err := compiled.Search(decoded)
 	if err != nil {
 		return Result{Error: err}
 	}
+	return Result{Value: result}
+}
 
+// runPath is the original, unmodified path walker: a missing key/index is
+// non-fatal only at the final path element; a miss at any intermediate
+// element is an error, and numeric path elements index arrays (including
+// from the end, via negative indices) but are looked up as ordinary
+// string keys against JSON objects.
+func (t *JSONParseTask) runPath(decoded interface{}) Result {
 	for i, part := range t.Path {
 		switch d := decoded.(type) {
 		case map[string]interface{}:
@@ -71,14 +133,17 @@ func (t *JSONParseTask) Run(inputs []Result) Result {
 			return Result{Error: errors.Errorf(`could not resolve path ["%v"]`, strings.Join(t.Path, `","`))}
 		}
 	}
-	return decoded, nil
+	return Result{Value: decoded}
 }
 
+// JSONPath is the legacy, pre-JMESPath representation of a JSON parse
+// path: a flat list of map keys and array indices, applied in order.
 type JSONPath []string
 
 func (p *JSONPath) Scan(value interface{}) error {
 	return json.Unmarshal(value.([]byte), p)
 }
+
 func (p JSONPath) Value() (driver.Value, error) {
 	return json.Marshal(p)
 }
@@ -0,0 +1,83 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONParseTask_Path(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		path            []string
+		wantData        interface{}
+		wantResultError bool
+	}{
+		{"existing path", `{"high":"11850.00","last":"11779.99"}`, []string{"last"},
+			"11779.99", false},
+		{"nonexistent leaf path", `{"high":"11850.00","last":"11779.99"}`, []string{"doesnotexist"},
+			nil, false},
+		{"nonexistent intermediate path is fatal", `{"high":"11850.00","last":"11779.99"}`, []string{"no", "really"},
+			nil, true},
+		{"array index path", `{"data":[{"availability":"0.99991"}]}`, []string{"data", "0", "availability"},
+			"0.99991", false},
+		{"index of negative one", `{"data": [0, 1]}`, []string{"data", "-1"},
+			float64(1), false},
+		{"numeric map key is looked up as a string, not an array index", `{"0":"first"}`, []string{"0"},
+			"first", false},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			task := JSONParseTask{Path: test.path}
+			result := task.Run([]Result{{Value: []byte(test.input)}})
+			if test.wantResultError {
+				require.Error(t, result.Error)
+			} else {
+				require.NoError(t, result.Error)
+				require.Equal(t, test.wantData, result.Value)
+			}
+		})
+	}
+}
+
+func TestJSONParseTask_Expression(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		expression      string
+		wantData        interface{}
+		wantResultError bool
+	}{
+		{"wildcard projection", `{"tickers":[{"last":1.1},{"last":2.2}]}`,
+			`tickers[*].last`, []interface{}{1.1, 2.2}, false},
+		{"filter + index", `{"data":[{"symbol":"ETH","price":"1.5"},{"symbol":"BTC","price":"2.5"}]}`,
+			`data[?symbol=='ETH'].price | [0]`, "1.5", false},
+		{"missing leaf is non-fatal", `{"data":{"a":1}}`,
+			`data.b`, nil, false},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			task := JSONParseTask{Expression: test.expression}
+			require.NoError(t, task.SetDefaults(nil, nil, nil))
+
+			result := task.Run([]Result{{Value: []byte(test.input)}})
+			if test.wantResultError {
+				require.Error(t, result.Error)
+			} else {
+				require.NoError(t, result.Error)
+				require.Equal(t, test.wantData, result.Value)
+			}
+		})
+	}
+}
+
+func TestJSONParseTask_SetDefaults_RejectsInvalidExpression(t *testing.T) {
+	task := JSONParseTask{Expression: `data[?symbol=='ETH'`}
+	err := task.SetDefaults(nil, nil, nil)
+	require.Error(t, err)
+}
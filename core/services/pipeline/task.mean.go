@@ -0,0 +1,33 @@
+package pipeline
+
+import (
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// MeanTask takes the arithmetic mean of N inputs, the way MedianTask takes
+// their median.
+type MeanTask struct {
+	BaseTask
+}
+
+var _ Task = (*MeanTask)(nil)
+
+func (t *MeanTask) Run(inputs []Result) Result {
+	if len(inputs) == 0 {
+		return Result{Error: errors.Wrap(ErrWrongInputCardinality, "MeanTask requires at least one input")}
+	}
+
+	sum := decimal.Zero
+	for _, input := range inputs {
+		if input.Error != nil {
+			return Result{Error: input.Error}
+		}
+		value, err := coerceToDecimal(input.Value)
+		if err != nil {
+			return Result{Error: err}
+		}
+		sum = sum.Add(value)
+	}
+	return Result{Value: sum.Div(decimal.New(int64(len(inputs)), 0))}
+}
@@ -0,0 +1,61 @@
+package pipeline
+
+import (
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// ModeTask takes the mode (most frequently occurring value) of N inputs,
+// always returning a single decimal.Decimal so that it composes with
+// downstream numeric tasks. If more than one value is tied for most
+// frequent, the smallest of them is returned, for a deterministic result.
+type ModeTask struct {
+	BaseTask
+}
+
+var _ Task = (*ModeTask)(nil)
+
+func (t *ModeTask) Run(inputs []Result) Result {
+	if len(inputs) == 0 {
+		return Result{Error: errors.Wrap(ErrWrongInputCardinality, "ModeTask requires at least one input")}
+	}
+
+	values := make([]decimal.Decimal, 0, len(inputs))
+	counts := make(map[string]int, len(inputs))
+	for _, input := range inputs {
+		if input.Error != nil {
+			return Result{Error: input.Error}
+		}
+		value, err := coerceToDecimal(input.Value)
+		if err != nil {
+			return Result{Error: err}
+		}
+		values = append(values, value)
+		counts[value.String()]++
+	}
+
+	maxCount := 0
+	for _, count := range counts {
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+
+	seen := make(map[string]bool, len(values))
+	var modes []decimal.Decimal
+	for _, value := range values {
+		key := value.String()
+		if counts[key] == maxCount && !seen[key] {
+			modes = append(modes, value)
+			seen[key] = true
+		}
+	}
+
+	smallest := modes[0]
+	for _, mode := range modes[1:] {
+		if mode.LessThan(smallest) {
+			smallest = mode
+		}
+	}
+	return Result{Value: smallest}
+}
@@ -0,0 +1,29 @@
+package pipeline
+
+import (
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// PowerTask raises its single input to a constant `exponent`, e.g.
+// `type=power exponent=2`.
+type PowerTask struct {
+	BaseTask
+	Exponent decimal.Decimal `json:"exponent"`
+}
+
+var _ Task = (*PowerTask)(nil)
+
+func (t *PowerTask) Run(inputs []Result) Result {
+	if len(inputs) != 1 {
+		return Result{Error: errors.Wrapf(ErrWrongInputCardinality, "PowerTask requires a single input")}
+	} else if inputs[0].Error != nil {
+		return Result{Error: inputs[0].Error}
+	}
+
+	value, err := coerceToDecimal(inputs[0].Value)
+	if err != nil {
+		return Result{Error: err}
+	}
+	return Result{Value: value.Pow(t.Exponent)}
+}
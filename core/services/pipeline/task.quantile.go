@@ -0,0 +1,52 @@
+package pipeline
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// QuantileTask takes the Q-th quantile (0 <= Q <= 1) of N inputs, e.g.
+// `type=quantile q=0.75` for the 75th percentile, linearly interpolating
+// between the two straddling ranks when Q*(N-1) isn't a whole number.
+// This makes Q=0.5 agree with MedianTask on both odd and even N.
+type QuantileTask struct {
+	BaseTask
+	Q decimal.Decimal `json:"q"`
+}
+
+var _ Task = (*QuantileTask)(nil)
+
+func (t *QuantileTask) Run(inputs []Result) Result {
+	if len(inputs) == 0 {
+		return Result{Error: errors.Wrap(ErrWrongInputCardinality, "QuantileTask requires at least one input")}
+	}
+	if t.Q.LessThan(decimal.Zero) || t.Q.GreaterThan(decimal.New(1, 0)) {
+		return Result{Error: errors.Errorf("QuantileTask: q must be between 0 and 1, got %s", t.Q)}
+	}
+
+	values := make([]decimal.Decimal, 0, len(inputs))
+	for _, input := range inputs {
+		if input.Error != nil {
+			return Result{Error: input.Error}
+		}
+		value, err := coerceToDecimal(input.Value)
+		if err != nil {
+			return Result{Error: err}
+		}
+		values = append(values, value)
+	}
+
+	sort.Slice(values, func(i, j int) bool { return values[i].LessThan(values[j]) })
+
+	rank := t.Q.Mul(decimal.New(int64(len(values)-1), 0))
+	lowerRank := rank.Floor()
+	upperRank := rank.Ceil()
+	lower := values[int(lowerRank.IntPart())]
+	upper := values[int(upperRank.IntPart())]
+	frac := rank.Sub(lowerRank)
+
+	result := lower.Add(upper.Sub(lower).Mul(frac))
+	return Result{Value: result}
+}
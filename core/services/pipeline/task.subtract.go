@@ -0,0 +1,29 @@
+package pipeline
+
+import (
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// SubtractTask subtracts a constant from its single input, e.g.
+// `type=subtract by=1.5`.
+type SubtractTask struct {
+	BaseTask
+	By decimal.Decimal `json:"by"`
+}
+
+var _ Task = (*SubtractTask)(nil)
+
+func (t *SubtractTask) Run(inputs []Result) Result {
+	if len(inputs) != 1 {
+		return Result{Error: errors.Wrapf(ErrWrongInputCardinality, "SubtractTask requires a single input")}
+	} else if inputs[0].Error != nil {
+		return Result{Error: inputs[0].Error}
+	}
+
+	value, err := coerceToDecimal(inputs[0].Value)
+	if err != nil {
+		return Result{Error: err}
+	}
+	return Result{Value: value.Sub(t.By)}
+}
@@ -0,0 +1,43 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+)
+
+// PipelineTaskRunsController lists the TaskRuns for a given pipeline run,
+// so operators can debug a misbehaving bridge or data source from the node
+// API instead of grepping logs.
+type PipelineTaskRunsController struct {
+	App chainlink.Application
+}
+
+// RegisterPipelineTaskRunsRoutes adds this controller's route to the given router group.
+// Call this alongside the node's other v2 routes in core/web/router.go's
+// NewRouter.
+func RegisterPipelineTaskRunsRoutes(r *gin.RouterGroup, app chainlink.Application) {
+	prc := &PipelineTaskRunsController{App: app}
+	r.GET("/v2/pipeline/runs/:pipelineRunID/task_runs", prc.Index)
+}
+
+// Index lists every TaskRun belonging to the pipeline run named by the
+// `pipelineRunID` route param.
+func (prc *PipelineTaskRunsController) Index(c *gin.Context) {
+	pipelineRunID, err := strconv.ParseInt(c.Param("pipelineRunID"), 10, 64)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	taskRuns, err := prc.App.GetStore().PipelineORM.FindTaskRunsByPipelineRunID(pipelineRunID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, taskRuns, "pipelineTaskRun")
+}
@@ -0,0 +1,28 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRegisterPipelineTaskRunsRoutes_RouteIsReachable proves the route
+// RegisterPipelineTaskRunsRoutes wires up is actually reachable through
+// gin's router, rather than only testing Index in isolation. It stops at
+// the pipelineRunID param-parsing error path, which doesn't require a
+// working chainlink.Application/store, since that dependency isn't
+// available to this package's tests.
+func TestRegisterPipelineTaskRunsRoutes_RouteIsReachable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterPipelineTaskRunsRoutes(r.Group("/"), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v2/pipeline/runs/not-a-number/task_runs", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}